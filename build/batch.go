@@ -0,0 +1,102 @@
+package build
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/skrashevich/gobinaries"
+	"github.com/skrashevich/gobinaries/storage"
+)
+
+// EventStatus is the lifecycle stage of a single target within a Batch.
+type EventStatus string
+
+// Event statuses, in the order a target moves through them.
+const (
+	EventQueued   EventStatus = "queued"
+	EventBuilding EventStatus = "building"
+	EventUploaded EventStatus = "uploaded"
+	EventError    EventStatus = "error"
+)
+
+// Event reports progress for a single target of a Batch build.
+type Event struct {
+	Target gobinaries.Binary `json:"target"`
+	Status EventStatus       `json:"status"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// BuildFunc builds a single target and uploads it to storage. It is supplied
+// by the caller, so Batch itself never writes to a storage.Store.
+type BuildFunc func(ctx context.Context, bin gobinaries.Binary) error
+
+// Batch builds multiple targets of a module@version concurrently, bounded by
+// a worker pool, deduplicating identical in-flight builds.
+type Batch struct {
+	// Concurrency is the maximum number of targets built at once. A value
+	// <= 0 means unbounded.
+	Concurrency int
+
+	// Prefix is the storage key prefix builds are deduplicated under. It
+	// should match the Prefix of the storage.Store targets are uploaded to.
+	Prefix string
+
+	group singleflight.Group
+}
+
+// targetKey returns the deduplication key for a single target, reusing
+// storage's own key format so dedup can never drift from where the build
+// actually gets uploaded.
+func (b *Batch) targetKey(bin gobinaries.Binary) string {
+	return storage.Key(b.Prefix, bin)
+}
+
+// Run builds targets by calling fn for each, reporting progress to events.
+// events is closed once every target has finished. Identical in-flight
+// targets (same module, version, os, arch and cgo setting) share a single
+// build via singleflight.
+func (b *Batch) Run(ctx context.Context, targets []gobinaries.Binary, fn BuildFunc, events chan<- Event) {
+	defer close(events)
+
+	sem := make(chan struct{}, b.workers(len(targets)))
+	done := make(chan struct{}, len(targets))
+
+	for _, bin := range targets {
+		bin := bin
+		events <- Event{Target: bin, Status: EventQueued}
+
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; done <- struct{}{} }()
+
+			events <- Event{Target: bin, Status: EventBuilding}
+
+			_, err, _ := b.group.Do(b.targetKey(bin), func() (interface{}, error) {
+				return nil, fn(ctx, bin)
+			})
+			if err != nil {
+				events <- Event{Target: bin, Status: EventError, Error: err.Error()}
+				return
+			}
+
+			events <- Event{Target: bin, Status: EventUploaded}
+		}()
+	}
+
+	for range targets {
+		<-done
+	}
+}
+
+// workers returns the worker pool size for n targets, bounded by
+// b.Concurrency when set.
+func (b *Batch) workers(n int) int {
+	if b.Concurrency <= 0 {
+		return n
+	}
+	if n < b.Concurrency {
+		return n
+	}
+	return b.Concurrency
+}
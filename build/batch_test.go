@@ -0,0 +1,25 @@
+package build
+
+import "testing"
+
+func TestBatchWorkers(t *testing.T) {
+	cases := []struct {
+		concurrency int
+		n           int
+		want        int
+	}{
+		{concurrency: 0, n: 5, want: 5},
+		{concurrency: -1, n: 5, want: 5},
+		{concurrency: 4, n: 10, want: 4},
+		{concurrency: 10, n: 4, want: 4},
+		{concurrency: 3, n: 3, want: 3},
+	}
+
+	for _, c := range cases {
+		b := &Batch{Concurrency: c.concurrency}
+		got := b.workers(c.n)
+		if got != c.want {
+			t.Errorf("Batch{Concurrency: %d}.workers(%d) = %d, want %d", c.concurrency, c.n, got, c.want)
+		}
+	}
+}
@@ -2,12 +2,14 @@
 package build
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -51,17 +53,23 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.err.Error(), e.stderr)
 }
 
-// Write a package binary to w.
-func Write(w io.Writer, bin gobinaries.Binary) error {
+// Write a package binary to w, returning the manifest extracted from its
+// embedded build info. The build is cancelled if ctx is done.
+//
+// If verify is true, the package is built a second time in a fresh temp
+// GOPATH and its digest compared against the first; a mismatch returns
+// ErrNotReproducible alongside the manifest, which still carries the digest
+// of the binary written to w.
+func Write(ctx context.Context, w io.Writer, bin gobinaries.Binary, verify bool) (*Manifest, error) {
 	dir, err := os.UserHomeDir()
 	dir = filepath.Join(dir, ".cache", "gobinaries", bin.Module)
 	if err != nil {
-		return fmt.Errorf("user home dir: %w", err)
+		return nil, fmt.Errorf("user home dir: %w", err)
 	}
 
-	err = install(bin, dir)
+	err = install(ctx, bin, dir)
 	if err != nil {
-		return fmt.Errorf("tidy module: %w", err)
+		return nil, fmt.Errorf("tidy module: %w", err)
 	}
 	var dst string
 	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
@@ -80,39 +88,68 @@ func Write(w io.Writer, bin gobinaries.Binary) error {
 	// check permissions and copy it to w
 	f, err := os.Open(dst)
 	if err != nil {
-		return fmt.Errorf("opening: %w", err)
+		return nil, fmt.Errorf("opening: %w", err)
 	}
 
 	info, err := f.Stat()
 	if err != nil {
-		return fmt.Errorf("stating: %w", err)
+		return nil, fmt.Errorf("stating: %w", err)
 	}
 
 	if !isExecutable(info.Mode()) {
-		return ErrNotExecutable
+		return nil, ErrNotExecutable
 	}
 
-	_, err = io.Copy(w, f)
+	// read the manifest back from the binary before it's copied out and
+	// the build dir is cleaned up.
+	manifest, err := ExtractManifest(dst)
 	if err != nil {
-		return fmt.Errorf("copying: %w", err)
+		return nil, fmt.Errorf("extracting manifest: %w", err)
 	}
 
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(w, h), f)
+	if err != nil {
+		return nil, fmt.Errorf("copying: %w", err)
+	}
+	manifest.Digest = hex.EncodeToString(h.Sum(nil))
+
 	err = f.Close()
 	if err != nil {
-		return fmt.Errorf("closing: %w", err)
+		return nil, fmt.Errorf("closing: %w", err)
 	}
 
 	err = os.RemoveAll(dir)
 	if err != nil {
-		return fmt.Errorf("cleaning: %w", err)
+		return nil, fmt.Errorf("cleaning: %w", err)
+	}
+
+	if verify {
+		reproducible := true
+		err = verifyReproducible(ctx, bin, manifest.Digest)
+		if err != nil {
+			if !errors.Is(err, ErrNotReproducible) {
+				return nil, fmt.Errorf("verifying reproducibility: %w", err)
+			}
+			reproducible = false
+		}
+		manifest.Reproducible = &reproducible
+		if !reproducible {
+			return manifest, err
+		}
 	}
-	return nil
+
+	return manifest, nil
 }
 
 // ClearCache removes the module cache.
-func ClearCache() error {
-	cmd := exec.Command("go", "clean", "--modcache")
-	return cmd.Run()
+func ClearCache(ctx context.Context) error {
+	inv := &Invocation{
+		Verb: "clean",
+		Args: []string{"--modcache"},
+	}
+	_, _, err := inv.Run(ctx)
+	return err
 }
 
 // isExecutable returns true if the exec bit is set for u/g/o.
@@ -123,29 +160,46 @@ func isExecutable(mode os.FileMode) bool {
 // addModule initializes a new go module in the given dir. This is apparently
 // necessary to build using Go modules since `go build` does not support
 // semver, awkward UX but oh well.
-func addModule(dir string) error {
-	cmd := exec.Command("go", "mod", "init", "github.com/gobinary")
-	cmd.Env = environ()
-	cmd.Env = append(cmd.Env, "GO111MODULE=on")
-	cmd.Dir = dir
-	return command(cmd)
+func addModule(ctx context.Context, dir string) error {
+	inv := &Invocation{
+		Verb:       "mod",
+		Args:       []string{"init", "github.com/gobinary"},
+		Env:        []string{"GO111MODULE=on"},
+		WorkingDir: dir,
+	}
+	_, _, err := inv.Run(ctx)
+	return err
 }
 
-func install(bin gobinaries.Binary, dir string) error {
-	ldflags := fmt.Sprintf("-s -w -X main.version=%s", bin.Version)
-	cmd := exec.Command("go", "install", "-trimpath", "-ldflags", ldflags, bin.Module+"/...@"+bin.Version)
-	cmd.Env = environ()
-	cmd.Env = append(cmd.Env, "GOPATH="+dir)
-	cmd.Env = append(cmd.Env, "CGO_ENABLED="+bin.CGO)
-	cmd.Env = append(cmd.Env, "GOOS="+bin.OS)
+// archEnv returns the GOARCH (and, for armv* targets, GOARM) environment
+// variables for bin.
+func archEnv(bin gobinaries.Binary) []string {
 	if strings.HasPrefix(bin.Arch, "armv") {
-		cmd.Env = append(cmd.Env, "GOARCH=arm")
-		cmd.Env = append(cmd.Env, "GOARM="+strings.TrimPrefix(bin.Arch, "armv"))
-	} else {
-		cmd.Env = append(cmd.Env, "GOARCH="+bin.Arch)
+		return []string{
+			"GOARCH=arm",
+			"GOARM=" + strings.TrimPrefix(bin.Arch, "armv"),
+		}
 	}
-	cmd.Dir, _ = os.UserHomeDir()
-	return command(cmd)
+	return []string{"GOARCH=" + bin.Arch}
+}
+
+func install(ctx context.Context, bin gobinaries.Binary, dir string) error {
+	homeDir, _ := os.UserHomeDir()
+	ldflags := fmt.Sprintf("-s -w -X main.version=%s", bin.Version)
+
+	inv := &Invocation{
+		Verb:       "install",
+		BuildFlags: []string{"-trimpath", "-ldflags", ldflags},
+		Args:       []string{bin.Module + "/...@" + bin.Version},
+		Env: append([]string{
+			"GOPATH=" + dir,
+			"CGO_ENABLED=" + bin.CGO,
+			"GOOS=" + bin.OS,
+		}, archEnv(bin)...),
+		WorkingDir: homeDir,
+	}
+	_, _, err := inv.Run(ctx)
+	return err
 }
 
 // getMajorVersion tries to detect the major version of the package.
@@ -172,26 +226,15 @@ func normalizeModuleDep(bin gobinaries.Binary) string {
 }
 
 // addModuleDep creates a module dependency.
-func addModuleDep(dir, dep string) error {
-	cmd := exec.Command("go", "mod", "edit", "-require", dep)
-	cmd.Env = environ()
-	cmd.Env = append(cmd.Env, "GO111MODULE=on")
-	cmd.Dir = dir
-	return command(cmd)
-}
-
-// command executes a command and capture stderr.
-func command(cmd *exec.Cmd) error {
-	var w strings.Builder
-	cmd.Stderr = &w
-	err := cmd.Run()
-	if err != nil {
-		return Error{
-			err:    err,
-			stderr: strings.TrimSpace(w.String()),
-		}
+func addModuleDep(ctx context.Context, dir, dep string) error {
+	inv := &Invocation{
+		Verb:       "mod",
+		Args:       []string{"edit", "-require", dep},
+		Env:        []string{"GO111MODULE=on"},
+		WorkingDir: dir,
 	}
-	return nil
+	_, _, err := inv.Run(ctx)
+	return err
 }
 
 // tempFilename returns a new temporary file name.
@@ -0,0 +1,27 @@
+package build
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+func TestArchEnv(t *testing.T) {
+	cases := []struct {
+		arch string
+		want []string
+	}{
+		{arch: "amd64", want: []string{"GOARCH=amd64"}},
+		{arch: "arm64", want: []string{"GOARCH=arm64"}},
+		{arch: "armv6", want: []string{"GOARCH=arm", "GOARM=6"}},
+		{arch: "armv7", want: []string{"GOARCH=arm", "GOARM=7"}},
+	}
+
+	for _, c := range cases {
+		got := archEnv(gobinaries.Binary{Arch: c.arch})
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("archEnv(%q) = %v, want %v", c.arch, got, c.want)
+		}
+	}
+}
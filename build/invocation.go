@@ -0,0 +1,88 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// Invocation represents a single `go` sub-command. It mirrors the design
+// used by x/tools' internal/gocommand: callers build up an Invocation then
+// Run or RunRaw it, rather than assembling *exec.Cmd by hand, so that env
+// whitelisting and module flags live in one place. Invocation does not
+// derive GOARCH/GOARM itself: callers compute them (see archEnv) and pass
+// them in Env.
+type Invocation struct {
+	// Verb is the `go` sub-command to run, e.g. "install", "mod", "clean".
+	Verb string
+
+	// Args are the arguments following Verb and any flags below.
+	Args []string
+
+	// BuildFlags are flags inserted directly after Verb, e.g. "-trimpath".
+	BuildFlags []string
+
+	// ModFlag, if set, is passed as -mod=<value>.
+	ModFlag string
+
+	// ModFile, if set, is passed as -modfile=<value>.
+	ModFile string
+
+	// Env holds additional environment variables beyond environWhitelist,
+	// e.g. GOOS, GOARCH, CGO_ENABLED.
+	Env []string
+
+	// WorkingDir is the directory the command is run from.
+	WorkingDir string
+}
+
+// args returns the full `go` argument list for this invocation.
+func (i *Invocation) args() []string {
+	args := []string{i.Verb}
+	args = append(args, i.BuildFlags...)
+	if i.ModFlag != "" {
+		args = append(args, "-mod="+i.ModFlag)
+	}
+	if i.ModFile != "" {
+		args = append(args, "-modfile="+i.ModFile)
+	}
+	return append(args, i.Args...)
+}
+
+// Run executes the invocation, returning its standard output and standard
+// error. The returned error is the friendly Error wrapping stderr, if the
+// command failed.
+func (i *Invocation) Run(ctx context.Context) (stdout, stderr []byte, err error) {
+	stdout, stderr, friendlyErr, rawErr := i.RunRaw(ctx)
+	if friendlyErr != nil {
+		return stdout, stderr, friendlyErr
+	}
+	return stdout, stderr, rawErr
+}
+
+// RunRaw executes the invocation like Run, but separates the friendly error
+// (an Error with trimmed stderr, suitable for displaying to users) from the
+// raw error returned by exec.Cmd.Run (suitable for logging and %w wrapping).
+func (i *Invocation) RunRaw(ctx context.Context) (stdout, stderr []byte, friendlyErr, rawErr error) {
+	cmd := exec.CommandContext(ctx, "go", i.args()...)
+	cmd.Env = append(environ(), i.Env...)
+	cmd.Dir = i.WorkingDir
+
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+
+	rawErr = cmd.Run()
+	stdout = outBuf.Bytes()
+	stderr = errBuf.Bytes()
+
+	if rawErr != nil {
+		friendlyErr = Error{
+			err:    rawErr,
+			stderr: strings.TrimSpace(errBuf.String()),
+		}
+	}
+
+	return stdout, stderr, friendlyErr, rawErr
+}
@@ -0,0 +1,84 @@
+package build
+
+import (
+	"debug/buildinfo"
+	"fmt"
+)
+
+// Dependency describes a single module dependency recorded in a binary's
+// embedded build info, mirroring the fields `go version -m` prints.
+type Dependency struct {
+	// Path is the module path.
+	Path string `json:"path"`
+
+	// Version is the resolved module version.
+	Version string `json:"version"`
+
+	// Sum is the module's go.sum hash, if known.
+	Sum string `json:"sum,omitempty"`
+}
+
+// Manifest describes the metadata embedded in a built Go binary by the
+// toolchain, as exposed by the debug/buildinfo package. It gives users a
+// verifiable, SBOM-like view of what actually got built.
+type Manifest struct {
+	// ModulePath is the main module's path.
+	ModulePath string `json:"module_path"`
+
+	// GoVersion is the Go toolchain version used to build the binary.
+	GoVersion string `json:"go_version"`
+
+	// VCSRevision is the source control revision embedded by the toolchain,
+	// if the build was done from a VCS checkout.
+	VCSRevision string `json:"vcs_revision,omitempty"`
+
+	// Deps is the full list of module dependencies with their versions.
+	Deps []Dependency `json:"deps"`
+
+	// Digest is the SHA-256 hex digest of the produced binary.
+	Digest string `json:"digest,omitempty"`
+
+	// Reproducible reports whether verify mode's second build produced an
+	// identical digest (false when the digests differed). Unset (nil) when
+	// verification wasn't requested.
+	Reproducible *bool `json:"reproducible,omitempty"`
+}
+
+// ExtractManifest reads the build info embedded in the binary at path and
+// returns it as a Manifest.
+func ExtractManifest(path string) (*Manifest, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading build info: %w", err)
+	}
+
+	m := &Manifest{
+		ModulePath: info.Main.Path,
+		GoVersion:  info.GoVersion,
+	}
+
+	for _, dep := range info.Deps {
+		d := Dependency{
+			Path:    dep.Path,
+			Version: dep.Version,
+		}
+		if dep.Sum != "" {
+			d.Sum = dep.Sum
+		}
+		// A replaced module's version/sum describe the replacement, not dep.
+		if dep.Replace != nil {
+			d.Path = dep.Replace.Path
+			d.Version = dep.Replace.Version
+			d.Sum = dep.Replace.Sum
+		}
+		m.Deps = append(m.Deps, d)
+	}
+
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			m.VCSRevision = setting.Value
+		}
+	}
+
+	return m, nil
+}
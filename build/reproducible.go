@@ -0,0 +1,91 @@
+package build
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// ErrNotReproducible is returned when a verify-mode rebuild produces a
+// binary whose digest doesn't match the original.
+var ErrNotReproducible = errors.New("build not reproducible")
+
+// reproducibilityError wraps ErrNotReproducible with a short diff summary
+// comparing the two digests.
+type reproducibilityError struct {
+	first, second string
+}
+
+func (e *reproducibilityError) Error() string {
+	return fmt.Sprintf("%s: %s != %s", ErrNotReproducible, e.first, e.second)
+}
+
+func (e *reproducibilityError) Unwrap() error {
+	return ErrNotReproducible
+}
+
+// verifyReproducible rebuilds bin from scratch in a fresh temp GOPATH with
+// identical flags and compares its digest against wantDigest. It returns
+// ErrNotReproducible (wrapped with a diff summary) if the digests differ.
+func verifyReproducible(ctx context.Context, bin gobinaries.Binary, wantDigest string) error {
+	dir, err := ioutil.TempDir("", "gobinaries-verify")
+	if err != nil {
+		return fmt.Errorf("making temp gopath: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = install(ctx, bin, dir)
+	if err != nil {
+		return fmt.Errorf("rebuilding: %w", err)
+	}
+
+	var dst string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && info.Mode().Perm()&0111 != 0 {
+			dst = path
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walking rebuild dir: %w", err)
+	}
+
+	digest, err := digestFile(dst)
+	if err != nil {
+		return fmt.Errorf("digesting rebuild: %w", err)
+	}
+
+	if digest != wantDigest {
+		return &reproducibilityError{first: wantDigest, second: digest}
+	}
+
+	return nil
+}
+
+// digestFile returns the hex SHA-256 digest of the file at path.
+func digestFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
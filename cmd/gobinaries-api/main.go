@@ -12,7 +12,6 @@ import (
 
 	"github.com/skrashevich/gobinaries/resolver"
 	"github.com/skrashevich/gobinaries/server"
-	"github.com/skrashevich/gobinaries/storage"
 )
 
 // main
@@ -28,6 +27,14 @@ func main() {
 		},
 	)
 
+	// storage
+	store, err := newStorage(ctx)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+	prefix := "production"
+	store.SetPrefix(prefix)
+
 	// server
 	addr := ":" + env.GetDefault("PORT", "3000")
 	s := &server.Server{
@@ -36,15 +43,14 @@ func main() {
 		Resolver: &resolver.GitHub{
 			Client: github.NewClient(oauth2.NewClient(ctx, gh)),
 		},
-		Storage: &storage.Local{
-
-			Prefix: "production",
-		},
+		Storage:      store,
+		Reproducible: env.GetDefault("REPRODUCIBLE_BUILDS", "") == "1",
+		Prefix:       prefix,
 	}
 
 	// listen
 	log.WithField("addr", addr).Info("starting server")
-	err := http.ListenAndServe(addr, s)
+	err = http.ListenAndServe(addr, s)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tj/go/env"
+
+	"github.com/skrashevich/gobinaries/storage"
+)
+
+// newStorage returns the storage.Store selected by the STORAGE_BACKEND
+// environment variable, defaulting to the local filesystem. Supported values
+// are "local", "s3", "gcs" and "azure".
+func newStorage(ctx context.Context) (storage.Store, error) {
+	switch backend := env.GetDefault("STORAGE_BACKEND", "local"); backend {
+	case "local":
+		return &storage.Local{}, nil
+	case "s3":
+		return storage.NewS3(ctx)
+	case "gcs":
+		return storage.NewGCS(ctx)
+	case "azure":
+		return storage.NewAzure()
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
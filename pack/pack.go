@@ -0,0 +1,203 @@
+// Package pack wraps a built binary into a distributable archive or Linux
+// package format.
+package pack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/goreleaser/nfpm/v2"
+	_ "github.com/goreleaser/nfpm/v2/apk"
+	_ "github.com/goreleaser/nfpm/v2/deb"
+	_ "github.com/goreleaser/nfpm/v2/rpm"
+	"github.com/goreleaser/nfpm/v2/files"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// Format is a distributable package format a binary can be wrapped in.
+type Format string
+
+// Supported formats.
+const (
+	FormatRaw   Format = "raw"
+	FormatTarGz Format = "tar.gz"
+	FormatZip   Format = "zip"
+	FormatDeb   Format = "deb"
+	FormatRpm   Format = "rpm"
+	FormatApk   Format = "apk"
+)
+
+// ErrUnsupportedFormat is returned by ParseFormat for an unrecognized value.
+var ErrUnsupportedFormat = errors.New("unsupported package format")
+
+// ParseFormat parses s, one of "raw", "tar.gz", "zip", "deb", "rpm" or "apk",
+// into a Format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatRaw, FormatTarGz, FormatZip, FormatDeb, FormatRpm, FormatApk:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, s)
+	}
+}
+
+// installPath returns the path the binary is installed to inside a package,
+// in the form /usr/local/bin/<name>.
+func installPath(bin gobinaries.Binary) string {
+	return path.Join("/usr/local/bin", path.Base(bin.Path))
+}
+
+// Write reads the raw binary from r and writes it wrapped in format to w.
+func Write(w io.Writer, format Format, bin gobinaries.Binary, r io.Reader) error {
+	switch format {
+	case FormatRaw, "":
+		_, err := io.Copy(w, r)
+		return err
+	case FormatTarGz:
+		return writeTarGz(w, bin, r)
+	case FormatZip:
+		return writeZip(w, bin, r)
+	case FormatDeb, FormatRpm, FormatApk:
+		return writePackage(w, format, bin, r)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// writeTarGz writes r as a single executable entry in a gzip-compressed tar
+// archive.
+func writeTarGz(w io.Writer, bin gobinaries.Binary, r io.Reader) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading binary: %w", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err = tw.WriteHeader(&tar.Header{
+		Name: path.Base(bin.Path),
+		Mode: 0755,
+		Size: int64(len(buf)),
+	})
+	if err != nil {
+		return fmt.Errorf("writing tar header: %w", err)
+	}
+
+	_, err = tw.Write(buf)
+	if err != nil {
+		return fmt.Errorf("writing tar entry: %w", err)
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+
+	return gw.Close()
+}
+
+// writeZip writes r as a single executable entry in a zip archive.
+func writeZip(w io.Writer, bin gobinaries.Binary, r io.Reader) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading binary: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+
+	hdr := &zip.FileHeader{
+		Name:   path.Base(bin.Path),
+		Method: zip.Deflate,
+	}
+	hdr.SetMode(0755)
+
+	fw, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("creating zip entry: %w", err)
+	}
+
+	_, err = fw.Write(buf)
+	if err != nil {
+		return fmt.Errorf("writing zip entry: %w", err)
+	}
+
+	return zw.Close()
+}
+
+// writePackage wraps r into a Linux package (deb, rpm or apk) via nfpm,
+// installing the binary at installPath(bin).
+func writePackage(w io.Writer, format Format, bin gobinaries.Binary, r io.Reader) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading binary: %w", err)
+	}
+
+	tmp, err := writeTempFile(buf)
+	if err != nil {
+		return fmt.Errorf("staging binary: %w", err)
+	}
+	defer removeTempFile(tmp)
+
+	name := path.Base(bin.Path)
+
+	info := &nfpm.Info{
+		Name:        name,
+		Arch:        bin.Arch,
+		Version:     bin.Version,
+		Maintainer:  "gobinaries",
+		Description: fmt.Sprintf("%s, packaged by gobinaries", bin.Path),
+		Overridables: nfpm.Overridables{
+			Contents: files.Contents{
+				&files.Content{
+					Source:      tmp,
+					Destination: installPath(bin),
+					FileInfo: &files.ContentFileInfo{
+						Mode: 0755,
+					},
+				},
+			},
+		},
+	}
+
+	packager, err := nfpm.Get(string(format))
+	if err != nil {
+		return fmt.Errorf("getting packager: %w", err)
+	}
+
+	err = packager.Package(nfpm.WithDefaults(info), w)
+	if err != nil {
+		return fmt.Errorf("packaging %s: %w", format, err)
+	}
+
+	return nil
+}
+
+// writeTempFile writes buf to a new temporary file and returns its path.
+func writeTempFile(buf []byte) (string, error) {
+	f, err := os.CreateTemp("", "gobinaries-pack")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = f.Write(buf)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// removeTempFile removes a file created by writeTempFile.
+func removeTempFile(path string) {
+	os.Remove(path)
+}
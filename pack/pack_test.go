@@ -0,0 +1,42 @@
+package pack
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{in: "raw", want: FormatRaw},
+		{in: "tar.gz", want: FormatTarGz},
+		{in: "zip", want: FormatZip},
+		{in: "deb", want: FormatDeb},
+		{in: "rpm", want: FormatRpm},
+		{in: "apk", want: FormatApk},
+		{in: "exe", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseFormat(c.in)
+		if c.wantErr {
+			if !errors.Is(err, ErrUnsupportedFormat) {
+				t.Errorf("ParseFormat(%q): expected ErrUnsupportedFormat, got %v", c.in, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseFormat(%q): unexpected error: %s", c.in, err)
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
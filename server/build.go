@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/skrashevich/gobinaries"
+	"github.com/skrashevich/gobinaries/build"
+)
+
+// buildRequest is the JSON body accepted by POST /build.
+type buildRequest struct {
+	Module  string        `json:"module"`
+	Version string        `json:"version"`
+	Targets []buildTarget `json:"targets"`
+}
+
+// buildTarget is a single OS/arch/cgo combination within a buildRequest.
+type buildTarget struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	CGO  string `json:"cgo"`
+}
+
+// serveBuild handles POST /build: it builds every requested target
+// concurrently via batch, streaming NDJSON progress events (queued/
+// building/uploaded/error) as each target finishes. batch is long-lived on
+// Server so that identical targets across separate requests are deduplicated
+// too, not just within one request's own target list.
+func serveBuild(ctx context.Context, w http.ResponseWriter, r *http.Request, batch *build.Batch, build_ func(ctx context.Context, bin gobinaries.Binary) error) {
+	var req buildRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	targets := make([]gobinaries.Binary, len(req.Targets))
+	for i, t := range req.Targets {
+		targets[i] = gobinaries.Binary{
+			Path:    req.Module,
+			Module:  req.Module,
+			Version: req.Version,
+			OS:      t.OS,
+			Arch:    t.Arch,
+			CGO:     t.CGO,
+		}
+	}
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	events := make(chan build.Event)
+	go batch.Run(ctx, targets, build_, events)
+
+	enc := json.NewEncoder(w)
+	for event := range events {
+		enc.Encode(event) //nolint:errcheck
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/skrashevich/gobinaries"
+	"github.com/skrashevich/gobinaries/build"
+	"github.com/skrashevich/gobinaries/storage"
+)
+
+// setDigestHeader sets X-Content-Digest on w from the manifest sidecar
+// stored for bin, if one exists. Errors are ignored since the digest header
+// is informational and must not block serving the binary itself.
+func setDigestHeader(ctx context.Context, store storage.Store, w http.ResponseWriter, bin gobinaries.Binary) {
+	r, err := store.GetManifest(ctx, bin)
+	if err != nil {
+		return
+	}
+	defer r.Close()
+
+	var m build.Manifest
+	if json.NewDecoder(r).Decode(&m) != nil {
+		return
+	}
+
+	if m.Digest != "" {
+		w.Header().Set("X-Content-Digest", m.Digest)
+	}
+}
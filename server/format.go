@@ -0,0 +1,26 @@
+package server
+
+import "net/http"
+
+// formatFromRequest determines the requested package format, preferring the
+// explicit ?format= query param and falling back to the Accept header.
+func formatFromRequest(r *http.Request) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f
+	}
+
+	switch r.Header.Get("Accept") {
+	case "application/gzip", "application/x-gtar":
+		return "tar.gz"
+	case "application/zip":
+		return "zip"
+	case "application/vnd.debian.binary-package":
+		return "deb"
+	case "application/x-rpm":
+		return "rpm"
+	case "application/x-apk":
+		return "apk"
+	default:
+		return "raw"
+	}
+}
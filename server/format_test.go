@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatFromRequest(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		accept string
+		want   string
+	}{
+		{name: "query param wins", url: "/foo@v1/linux/amd64?format=zip", accept: "application/gzip", want: "zip"},
+		{name: "tar.gz via accept", url: "/foo@v1/linux/amd64", accept: "application/gzip", want: "tar.gz"},
+		{name: "zip via accept", url: "/foo@v1/linux/amd64", accept: "application/zip", want: "zip"},
+		{name: "deb via accept", url: "/foo@v1/linux/amd64", accept: "application/vnd.debian.binary-package", want: "deb"},
+		{name: "default raw", url: "/foo@v1/linux/amd64", accept: "", want: "raw"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, c.url, nil)
+			if c.accept != "" {
+				r.Header.Set("Accept", c.accept)
+			}
+
+			got := formatFromRequest(r)
+			if got != c.want {
+				t.Errorf("formatFromRequest() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package server exposes HTTP handlers serving built binaries and their
+// metadata.
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// serveManifest handles GET /manifest/<pkg>@<ver>/<os>/<arch>, returning the
+// JSON manifest recorded for the binary when it was built.
+func serveManifest(ctx context.Context, s *Server, w http.ResponseWriter, path string) {
+	bin, err := parseBinaryPath(strings.TrimPrefix(path, "/manifest/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bin, err = s.resolveVersion(ctx, bin)
+	if err != nil {
+		http.Error(w, "error resolving version", http.StatusInternalServerError)
+		return
+	}
+
+	r, err := s.Storage.GetManifest(ctx, bin)
+	if err != nil {
+		if err == gobinaries.ErrObjectNotFound {
+			http.Error(w, "manifest not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "error fetching manifest", http.StatusInternalServerError)
+		return
+	}
+	defer r.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	io.Copy(w, r)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// errInvalidBinaryPath is returned when a request path doesn't match
+// <pkg>@<version>/<os>/<arch>.
+var errInvalidBinaryPath = errors.New("invalid binary path")
+
+// parseBinaryPath parses path into a gobinaries.Binary. path is of the form
+// <pkg>@<version>/<os>/<arch>, e.g. github.com/foo/bar@v1.2.3/linux/amd64.
+// The module path itself may contain any number of "/" separators, so the
+// trailing os/arch segments are split off first, and the remainder is then
+// split on its last "@" into pkg and version.
+func parseBinaryPath(path string) (gobinaries.Binary, error) {
+	path = strings.Trim(path, "/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return gobinaries.Binary{}, errInvalidBinaryPath
+	}
+
+	arch := parts[len(parts)-1]
+	os := parts[len(parts)-2]
+	pkgVer := strings.Join(parts[:len(parts)-2], "/")
+
+	at := strings.LastIndex(pkgVer, "@")
+	if at < 0 {
+		return gobinaries.Binary{}, errInvalidBinaryPath
+	}
+
+	pkg := pkgVer[:at]
+	version := pkgVer[at+1:]
+	if pkg == "" || version == "" || os == "" || arch == "" {
+		return gobinaries.Binary{}, errInvalidBinaryPath
+	}
+
+	return gobinaries.Binary{
+		Path:    pkg,
+		Module:  pkg,
+		Version: version,
+		OS:      os,
+		Arch:    arch,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package server
+
+import "testing"
+
+func TestParseBinaryPath(t *testing.T) {
+	cases := []struct {
+		path    string
+		want    string // Path@Version/OS/Arch, reconstructed
+		wantErr bool
+	}{
+		{
+			path: "github.com/foo/bar@v1.2.3/linux/amd64",
+			want: "github.com/foo/bar@v1.2.3/linux/amd64",
+		},
+		{
+			path: "/github.com/foo/bar@v1.2.3/linux/amd64/",
+			want: "github.com/foo/bar@v1.2.3/linux/amd64",
+		},
+		{
+			path: "foo@v1/linux/amd64",
+			want: "foo@v1/linux/amd64",
+		},
+		{
+			path:    "github.com/foo/bar/linux/amd64",
+			wantErr: true,
+		},
+		{
+			path:    "linux/amd64",
+			wantErr: true,
+		},
+		{
+			path:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		bin, err := parseBinaryPath(c.path)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseBinaryPath(%q): expected error, got %+v", c.path, bin)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("parseBinaryPath(%q): unexpected error: %s", c.path, err)
+			continue
+		}
+
+		got := bin.Path + "@" + bin.Version + "/" + bin.OS + "/" + bin.Arch
+		if got != c.want {
+			t.Errorf("parseBinaryPath(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/skrashevich/gobinaries"
+	"github.com/skrashevich/gobinaries/build"
+	"github.com/skrashevich/gobinaries/pack"
+	"github.com/skrashevich/gobinaries/storage"
+)
+
+// Resolver resolves a module path and version constraint (e.g. "latest")
+// to a concrete, buildable version.
+type Resolver interface {
+	Resolve(ctx context.Context, module, version string) (string, error)
+}
+
+// Server serves built Go binaries over HTTP, building and caching them in
+// Storage on demand.
+type Server struct {
+	// Static is the directory static assets are served from.
+	Static string
+
+	// URL is the externally reachable base URL of the server.
+	URL string
+
+	// Resolver resolves module version constraints to concrete versions.
+	Resolver Resolver
+
+	// Storage is the object store binaries and their manifests are cached in.
+	Storage storage.Store
+
+	// Reproducible, when true, makes every build verify reproducibility by
+	// default even without an explicit ?verify=1 query param.
+	Reproducible bool
+
+	// Prefix is the storage key prefix, matching Storage's own prefix, that
+	// concurrent builds are deduplicated under. See batch.
+	Prefix string
+
+	batchOnce sync.Once
+	batch     *build.Batch
+}
+
+// sharedBatch returns the long-lived build.Batch used to serve POST /build,
+// constructing it on first use. It must be long-lived (not one per request)
+// so that identical in-flight targets requested by different clients of this
+// process actually share a build via singleflight, rather than each request
+// getting its own dedup scope. Builds running on other replicas are not
+// deduplicated against.
+func (s *Server) sharedBatch() *build.Batch {
+	s.batchOnce.Do(func() {
+		s.batch = &build.Batch{Concurrency: 4, Prefix: s.Prefix}
+	})
+	return s.batch
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/manifest/"):
+		serveManifest(ctx, s, w, r.URL.Path)
+	case r.Method == http.MethodPost && r.URL.Path == "/build":
+		serveBuild(ctx, w, r, s.sharedBatch(), s.buildAndStore)
+	default:
+		s.serveBinary(ctx, w, r)
+	}
+}
+
+// resolveVersion resolves bin's version constraint (e.g. "latest") to a
+// concrete version via s.Resolver, leaving bin unchanged if no Resolver is
+// configured.
+func (s *Server) resolveVersion(ctx context.Context, bin gobinaries.Binary) (gobinaries.Binary, error) {
+	if s.Resolver == nil {
+		return bin, nil
+	}
+
+	version, err := s.Resolver.Resolve(ctx, bin.Module, bin.Version)
+	if err != nil {
+		return bin, err
+	}
+
+	bin.Version = version
+	return bin, nil
+}
+
+// buildAndStore builds a single target and uploads it (and its manifest) to
+// Storage. It's the build.BuildFunc passed to build.Batch by serveBuild.
+func (s *Server) buildAndStore(ctx context.Context, bin gobinaries.Binary) error {
+	var buf bytes.Buffer
+	manifest, err := build.Write(ctx, &buf, bin, s.Reproducible)
+	if err != nil && !errors.Is(err, build.ErrNotReproducible) {
+		return err
+	}
+
+	err = s.Storage.Create(ctx, bytes.NewReader(buf.Bytes()), bin)
+	if err != nil {
+		return err
+	}
+
+	if manifest != nil {
+		manifestJSON, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		return s.Storage.CreateManifest(ctx, bytes.NewReader(manifestJSON), bin)
+	}
+
+	return nil
+}
+
+// serveBinary handles GET /<pkg>@<version>/<os>/<arch>, serving the binary
+// from Storage if it's already been built, and building and caching it
+// otherwise. Storage always holds the raw, unpackaged binary; the requested
+// format is applied on every serve so that format selection (?format= /
+// Accept) holds regardless of what format, if any, earlier requests asked
+// for.
+func (s *Server) serveBinary(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	bin, err := parseBinaryPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bin, err = s.resolveVersion(ctx, bin)
+	if err != nil {
+		http.Error(w, "error resolving version", http.StatusInternalServerError)
+		return
+	}
+
+	format, err := pack.ParseFormat(formatFromRequest(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if cached, err := s.Storage.Get(ctx, bin); err == nil {
+		defer cached.Close()
+
+		var packaged bytes.Buffer
+		if err := pack.Write(&packaged, format, bin, cached); err != nil {
+			http.Error(w, "packaging failed", http.StatusInternalServerError)
+			return
+		}
+
+		setDigestHeader(ctx, s.Storage, w, bin)
+		w.Write(packaged.Bytes())
+		return
+	} else if !errors.Is(err, gobinaries.ErrObjectNotFound) {
+		http.Error(w, "error fetching binary", http.StatusInternalServerError)
+		return
+	}
+
+	verify := s.Reproducible || verifyFromRequest(r)
+
+	var buf bytes.Buffer
+	manifest, err := build.Write(ctx, &buf, bin, verify)
+	if err != nil && !errors.Is(err, build.ErrNotReproducible) {
+		http.Error(w, "build failed", http.StatusInternalServerError)
+		return
+	}
+	notReproducible := errors.Is(err, build.ErrNotReproducible)
+	raw := buf.Bytes()
+
+	err = s.Storage.Create(ctx, bytes.NewReader(raw), bin)
+	if err != nil {
+		http.Error(w, "storing binary failed", http.StatusInternalServerError)
+		return
+	}
+
+	if manifest != nil {
+		manifestJSON, err := json.Marshal(manifest)
+		if err == nil {
+			s.Storage.CreateManifest(ctx, bytes.NewReader(manifestJSON), bin) //nolint:errcheck
+		}
+		if manifest.Digest != "" {
+			w.Header().Set("X-Content-Digest", manifest.Digest)
+		}
+	}
+
+	if verify {
+		w.Header().Set("X-Reproducible", strconv.FormatBool(!notReproducible))
+	}
+
+	var packaged bytes.Buffer
+	if err := pack.Write(&packaged, format, bin, bytes.NewReader(raw)); err != nil {
+		http.Error(w, "packaging failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(packaged.Bytes())
+}
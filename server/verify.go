@@ -0,0 +1,9 @@
+package server
+
+import "net/http"
+
+// verifyFromRequest reports whether reproducible-build verification was
+// requested via ?verify=1.
+func verifyFromRequest(r *http.Request) bool {
+	return r.URL.Query().Get("verify") == "1"
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/tj/go/env"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// Azure is an Azure Blob Storage object store for binaries.
+type Azure struct {
+	// Container is the destination container name.
+	Container string
+
+	// Prefix is an optional object key prefix.
+	Prefix string
+
+	url azblob.ContainerURL
+}
+
+// NewAzure returns an Azure store configured from the environment. It reads
+// AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_ACCESS_KEY for credentials, and
+// STORAGE_AZURE_CONTAINER for the container name.
+func NewAzure() (*Azure, error) {
+	account := env.Get("AZURE_STORAGE_ACCOUNT")
+	key := env.Get("AZURE_STORAGE_ACCESS_KEY")
+	container := env.Get("STORAGE_AZURE_CONTAINER")
+
+	cred, err := azblob.NewSharedKeyCredential(account, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure credential: %w", err)
+	}
+
+	pipeline := azblob.NewPipeline(cred, azblob.PipelineOptions{})
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s", account, container))
+	if err != nil {
+		return nil, fmt.Errorf("parsing azure url: %w", err)
+	}
+
+	return &Azure{
+		Container: container,
+		url:       azblob.NewContainerURL(*u, pipeline),
+	}, nil
+}
+
+// Create an object representing the package's binary.
+func (a *Azure) Create(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := a.getKey(bin)
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading: %w", err)
+	}
+
+	blob := a.url.NewBlockBlobURL(key)
+	_, err = azblob.UploadBufferToBlockBlob(ctx, buf, blob, azblob.UploadToBlockBlobOptions{})
+	if err != nil {
+		return fmt.Errorf("uploading to azure: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns an object.
+func (a *Azure) Get(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := a.getKey(bin)
+
+	blob := a.url.NewBlockBlobURL(key)
+	resp, err := blob.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching from azure: %w", err)
+	}
+
+	return resp.Body(azblob.RetryReaderOptions{}), nil
+}
+
+// CreateManifest stores r as the JSON manifest sidecar for bin.
+func (a *Azure) CreateManifest(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := a.getManifestKey(bin)
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading: %w", err)
+	}
+
+	blob := a.url.NewBlockBlobURL(key)
+	_, err = azblob.UploadBufferToBlockBlob(ctx, buf, blob, azblob.UploadToBlockBlobOptions{})
+	if err != nil {
+		return fmt.Errorf("uploading manifest to azure: %w", err)
+	}
+
+	return nil
+}
+
+// GetManifest returns the JSON manifest sidecar stored for bin.
+func (a *Azure) GetManifest(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := a.getManifestKey(bin)
+
+	blob := a.url.NewBlockBlobURL(key)
+	resp, err := blob.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching manifest from azure: %w", err)
+	}
+
+	return resp.Body(azblob.RetryReaderOptions{}), nil
+}
+
+// SetPrefix sets the object key prefix.
+func (a *Azure) SetPrefix(prefix string) {
+	a.Prefix = prefix
+}
+
+// getKey returns the object key in the form <prefix>/<pkg>/<binary>.
+func (a *Azure) getKey(bin gobinaries.Binary) string {
+	return Key(a.Prefix, bin)
+}
+
+// getManifestKey returns the sidecar manifest key for bin.
+func (a *Azure) getManifestKey(bin gobinaries.Binary) string {
+	return ManifestKey(a.Prefix, bin)
+}
+
+// isAzureNotFound reports whether err represents a missing blob.
+func isAzureNotFound(err error) bool {
+	if serr, ok := err.(azblob.StorageError); ok {
+		return serr.ServiceCode() == azblob.ServiceCodeBlobNotFound
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	gcstorage "cloud.google.com/go/storage"
+	"github.com/tj/go/env"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// GCS is a Google Cloud Storage object store for binaries.
+type GCS struct {
+	// Bucket is the destination bucket name.
+	Bucket string
+
+	// Prefix is an optional object key prefix.
+	Prefix string
+
+	client *gcstorage.Client
+}
+
+// NewGCS returns a GCS store configured from the environment. It reads
+// STORAGE_GCS_BUCKET for the bucket name. Credentials are resolved the usual
+// way via GOOGLE_APPLICATION_CREDENTIALS.
+func NewGCS(ctx context.Context) (*GCS, error) {
+	client, err := gcstorage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating gcs client: %w", err)
+	}
+
+	return &GCS{
+		Bucket: env.Get("STORAGE_GCS_BUCKET"),
+		client: client,
+	}, nil
+}
+
+// Create an object representing the package's binary.
+func (g *GCS) Create(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := g.getKey(bin)
+
+	w := g.client.Bucket(g.Bucket).Object(key).NewWriter(ctx)
+
+	_, err := io.Copy(w, r)
+	if err != nil {
+		return fmt.Errorf("copying to gcs: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("closing gcs writer: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns an object.
+func (g *GCS) Get(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := g.getKey(bin)
+
+	r, err := g.client.Bucket(g.Bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		if err == gcstorage.ErrObjectNotExist {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching from gcs: %w", err)
+	}
+
+	return r, nil
+}
+
+// CreateManifest stores r as the JSON manifest sidecar for bin.
+func (g *GCS) CreateManifest(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := g.getManifestKey(bin)
+
+	w := g.client.Bucket(g.Bucket).Object(key).NewWriter(ctx)
+
+	_, err := io.Copy(w, r)
+	if err != nil {
+		return fmt.Errorf("copying manifest to gcs: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("closing gcs writer: %w", err)
+	}
+
+	return nil
+}
+
+// GetManifest returns the JSON manifest sidecar stored for bin.
+func (g *GCS) GetManifest(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := g.getManifestKey(bin)
+
+	r, err := g.client.Bucket(g.Bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		if err == gcstorage.ErrObjectNotExist {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching manifest from gcs: %w", err)
+	}
+
+	return r, nil
+}
+
+// SetPrefix sets the object key prefix.
+func (g *GCS) SetPrefix(prefix string) {
+	g.Prefix = prefix
+}
+
+// getKey returns the object key in the form <prefix>/<pkg>/<binary>.
+func (g *GCS) getKey(bin gobinaries.Binary) string {
+	return Key(g.Prefix, bin)
+}
+
+// getManifestKey returns the sidecar manifest key for bin.
+func (g *GCS) getManifestKey(bin gobinaries.Binary) string {
+	return ManifestKey(g.Prefix, bin)
+}
@@ -7,7 +7,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/skrashevich/gobinaries"
 )
@@ -65,11 +64,56 @@ func (l *Local) Get(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser,
 	return f, nil
 }
 
+// CreateManifest stores r as the JSON manifest sidecar for bin.
+func (l *Local) CreateManifest(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := l.getManifestKey(bin)
+	path := filepath.Join(l.Root, key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("making directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		return fmt.Errorf("copying: %w", err)
+	}
+
+	return nil
+}
+
+// GetManifest returns the JSON manifest sidecar stored for bin.
+func (l *Local) GetManifest(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := l.getManifestKey(bin)
+	path := filepath.Join(l.Root, key)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+
+	return f, nil
+}
+
+// getManifestKey returns the sidecar manifest key for bin, in the form
+// <prefix>/<pkg>/<binary>.json.
+func (l *Local) getManifestKey(bin gobinaries.Binary) string {
+	return ManifestKey(l.Prefix, bin)
+}
+
 // getKey returns the object key in the form <pkg>/<binary>.
 func (l *Local) getKey(bin gobinaries.Binary) string {
-	dir := l.Prefix + "/" + strings.Replace(bin.Path, "/", "-", -1)
-	file := fmt.Sprintf("%s-%s-%s-%s", bin.Version, bin.OS, bin.Arch, bin.CGO)
-	return filepath.Join(dir, file)
+	return Key(l.Prefix, bin)
 }
 
 func (l *Local) SetPrefix(prefix string) {
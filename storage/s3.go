@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/tj/go/env"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// S3 is an Amazon S3 object store for binaries.
+type S3 struct {
+	// Bucket is the destination bucket name.
+	Bucket string
+
+	// Prefix is an optional object key prefix.
+	Prefix string
+
+	client *s3.Client
+}
+
+// NewS3 returns an S3 store configured from the environment. It reads
+// STORAGE_S3_BUCKET for the bucket name, and the usual AWS credential/region
+// environment variables (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION,
+// etc.) via the default AWS config chain.
+func NewS3(ctx context.Context) (*S3, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(env.GetDefault("AWS_REGION", "us-east-1")))
+	if err != nil {
+		return nil, fmt.Errorf("loading aws config: %w", err)
+	}
+
+	return &S3{
+		Bucket: env.Get("STORAGE_S3_BUCKET"),
+		client: s3.NewFromConfig(cfg),
+	}, nil
+}
+
+// Create an object representing the package's binary.
+func (s *S3) Create(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := s.getKey(bin)
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading: %w", err)
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(key),
+		Body:   strings.NewReader(string(buf)),
+	})
+	if err != nil {
+		return fmt.Errorf("uploading to s3: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns an object.
+func (s *S3) Get(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := s.getKey(bin)
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching from s3: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+// CreateManifest stores r as the JSON manifest sidecar for bin.
+func (s *S3) CreateManifest(ctx context.Context, r io.Reader, bin gobinaries.Binary) error {
+	key := s.getManifestKey(bin)
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading: %w", err)
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(key),
+		Body:   strings.NewReader(string(buf)),
+	})
+	if err != nil {
+		return fmt.Errorf("uploading manifest to s3: %w", err)
+	}
+
+	return nil
+}
+
+// GetManifest returns the JSON manifest sidecar stored for bin.
+func (s *S3) GetManifest(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error) {
+	key := s.getManifestKey(bin)
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, gobinaries.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("fetching manifest from s3: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+// SetPrefix sets the object key prefix.
+func (s *S3) SetPrefix(prefix string) {
+	s.Prefix = prefix
+}
+
+// getKey returns the object key in the form <prefix>/<pkg>/<binary>.
+func (s *S3) getKey(bin gobinaries.Binary) string {
+	return Key(s.Prefix, bin)
+}
+
+// getManifestKey returns the sidecar manifest key for bin.
+func (s *S3) getManifestKey(bin gobinaries.Binary) string {
+	return ManifestKey(s.Prefix, bin)
+}
+
+// isNotFound reports whether err represents a missing S3 object.
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "NoSuchKey") || strings.Contains(err.Error(), "NotFound")
+}
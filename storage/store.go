@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/skrashevich/gobinaries"
+)
+
+// Store is the interface implemented by binary object stores. Implementations
+// persist the binary produced for a given gobinaries.Binary and allow it to
+// be retrieved again later, sharing a build cache across replicas.
+type Store interface {
+	// Create an object representing the package's binary.
+	Create(ctx context.Context, r io.Reader, bin gobinaries.Binary) error
+
+	// Get returns an object.
+	Get(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error)
+
+	// CreateManifest stores r as the JSON manifest sidecar for bin.
+	CreateManifest(ctx context.Context, r io.Reader, bin gobinaries.Binary) error
+
+	// GetManifest returns the JSON manifest sidecar stored for bin.
+	GetManifest(ctx context.Context, bin gobinaries.Binary) (io.ReadCloser, error)
+
+	// SetPrefix sets the object key prefix.
+	SetPrefix(prefix string)
+}
+
+// Ensure the built-in implementations satisfy Store.
+var (
+	_ Store = (*Local)(nil)
+	_ Store = (*S3)(nil)
+	_ Store = (*GCS)(nil)
+	_ Store = (*Azure)(nil)
+)
+
+// Key returns the canonical object key for bin under prefix, in the form
+// <prefix>/<pkg>/<binary>. All Store implementations derive their object
+// keys from this, so callers needing to reason about identity (e.g.
+// deduplicating in-flight builds) can use it instead of re-deriving their
+// own key format.
+func Key(prefix string, bin gobinaries.Binary) string {
+	dir := prefix + "/" + strings.Replace(bin.Path, "/", "-", -1)
+	file := fmt.Sprintf("%s-%s-%s-%s", bin.Version, bin.OS, bin.Arch, bin.CGO)
+	return dir + "/" + file
+}
+
+// ManifestKey returns the sidecar manifest key for bin under prefix.
+func ManifestKey(prefix string, bin gobinaries.Binary) string {
+	return Key(prefix, bin) + ".json"
+}